@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Hades32/temporal-benchmark-matrix/pkg/apply"
+)
+
+var applyOpts apply.Options
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Discover dashboard YAML under --dashboards-dir and upsert it into Grafana",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applyOpts.GrafanaHost = grafanaHost
+		applyOpts.GrafanaToken = grafanaToken
+
+		summary, runErr := apply.Run(cmd.Context(), applyOpts)
+
+		out, err := apply.MarshalSummary(summary)
+		if err != nil {
+			return fmt.Errorf("marshal summary: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+
+		return runErr
+	},
+}
+
+func init() {
+	applyCmd.Flags().StringVar(&applyOpts.DashboardsDir, "dashboards-dir", "./dashboards", "directory to walk for dashboard YAML files")
+	applyCmd.Flags().StringVar(&applyOpts.ValuesPath, "values", "./dashboards/values.yaml", "Helm-style values file for template expansion")
+	applyCmd.Flags().StringVar(&applyOpts.ManifestPath, "manifest", "./dashboards/.manifest.json", "path to the apply manifest used for drift detection")
+	applyCmd.Flags().StringVar(&applyOpts.DatasourcesPath, "datasources", "./dashboards/datasources.yaml", "datasources to provision before upserting dashboards")
+	applyCmd.Flags().StringVar(&applyOpts.SecretsDir, "secrets-dir", "./secrets", "directory holding datasource secret files referenced by file: refs")
+	applyCmd.Flags().IntVar(&applyOpts.Concurrency, "concurrency", 4, "max dashboards upserted concurrently")
+	applyCmd.Flags().BoolVar(&applyOpts.DryRun, "dry-run", false, "report what would change without upserting dashboards, provisioning datasources, or writing the manifest")
+	applyCmd.Flags().BoolVar(&applyOpts.Prune, "prune", false, "delete orphaned dashboards found in Grafana")
+	applyCmd.Flags().BoolVar(&applyOpts.FailOnDrift, "fail-on-drift", false, "exit non-zero if drift or orphans are detected")
+
+	rootCmd.AddCommand(applyCmd)
+}
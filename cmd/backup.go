@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Hades32/temporal-benchmark-matrix/pkg/backup"
+)
+
+var backupOpts backup.Options
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Pull every dashboard from a Grafana folder into a git working tree",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		backupOpts.GrafanaHost = grafanaHost
+		backupOpts.GrafanaToken = grafanaToken
+
+		results, err := backup.Backup(cmd.Context(), backupOpts)
+		if err != nil {
+			return err
+		}
+
+		out, marshalErr := json.MarshalIndent(results, "", "  ")
+		if marshalErr != nil {
+			return fmt.Errorf("marshal summary: %w", marshalErr)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+		return nil
+	},
+}
+
+func init() {
+	backupCmd.Flags().StringVar(&backupOpts.Folder, "folder", "Benchmarks", "Grafana folder to back up")
+	backupCmd.Flags().StringVar(&backupOpts.RepoDir, "repo-dir", "./dashboards-backup", "git working tree to write dashboards into")
+	backupCmd.Flags().BoolVar(&backupOpts.Commit, "commit", false, "commit the written dashboards")
+	backupCmd.Flags().BoolVar(&backupOpts.Push, "push", false, "push after committing")
+	backupCmd.Flags().StringVar(&backupOpts.Message, "message", "", "commit message (default: auto-generated)")
+
+	rootCmd.AddCommand(backupCmd)
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Hades32/temporal-benchmark-matrix/pkg/bench"
+)
+
+var (
+	reportOpts  bench.ReportOptions
+	reportStart string
+	reportEnd   string
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Ingest go test -bench output and annotate the benchmark dashboards with this run",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reportOpts.GrafanaHost = grafanaHost
+		reportOpts.GrafanaToken = grafanaToken
+
+		start, end, err := parseRunWindow(reportStart, reportEnd)
+		if err != nil {
+			return err
+		}
+		reportOpts.Start = start
+		reportOpts.End = end
+
+		return bench.Report(cmd.Context(), reportOpts)
+	},
+}
+
+// parseRunWindow parses --start/--end as RFC3339, defaulting both to the
+// current time (a point annotation) when the caller doesn't know the run's
+// actual wall-clock duration.
+func parseRunWindow(start, end string) (time.Time, time.Time, error) {
+	now := time.Now()
+
+	startTime := now
+	if start != "" {
+		parsed, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("parse --start: %w", err)
+		}
+		startTime = parsed
+	}
+
+	endTime := now
+	if end != "" {
+		parsed, err := time.Parse(time.RFC3339, end)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("parse --end: %w", err)
+		}
+		endTime = parsed
+	} else if start != "" {
+		endTime = startTime
+	}
+
+	return startTime, endTime, nil
+}
+
+func init() {
+	reportCmd.Flags().StringVar(&reportOpts.InputPath, "input", "", "path to go test -bench output (required)")
+	reportCmd.Flags().StringVar(&reportOpts.RunID, "run-id", "", "identifier correlating this run across dashboards and pushgateway series (required)")
+	reportCmd.Flags().StringVar(&reportOpts.Commit, "commit", "", "commit SHA under test (required)")
+	reportCmd.Flags().StringVar(&reportOpts.TemporalVersion, "temporal-version", "", "Temporal server version under test")
+	reportCmd.Flags().StringVar(&reportOpts.WorkflowSDKVersion, "workflow-sdk-version", "", "Temporal workflow SDK version under test")
+	reportCmd.Flags().StringVar(&reportOpts.PushgatewayURL, "pushgateway", "", "Prometheus pushgateway base URL; skipped if empty")
+	reportCmd.Flags().StringVar(&reportOpts.Folder, "folder", "Benchmarks", "Grafana folder whose dashboards get annotated")
+	reportCmd.Flags().StringVar(&reportOpts.Format, "format", "gotest", "input format: \"gotest\" (go test -bench output) or \"benchstat-csv\" (benchstat -format csv, sec/op only)")
+	reportCmd.Flags().StringVar(&reportStart, "start", "", "RFC3339 run start time (default: now)")
+	reportCmd.Flags().StringVar(&reportEnd, "end", "", "RFC3339 run end time (default: --start, or now)")
+
+	_ = reportCmd.MarkFlagRequired("input")
+	_ = reportCmd.MarkFlagRequired("run-id")
+	_ = reportCmd.MarkFlagRequired("commit")
+
+	rootCmd.AddCommand(reportCmd)
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Hades32/temporal-benchmark-matrix/pkg/backup"
+)
+
+var restoreOpts backup.RestoreOptions
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Upsert every dashboard JSON file in a git working tree back into Grafana",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		restoreOpts.GrafanaHost = grafanaHost
+		restoreOpts.GrafanaToken = grafanaToken
+
+		results, err := backup.Restore(cmd.Context(), restoreOpts)
+		if err != nil {
+			return err
+		}
+
+		out, marshalErr := json.MarshalIndent(results, "", "  ")
+		if marshalErr != nil {
+			return fmt.Errorf("marshal summary: %w", marshalErr)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+		return nil
+	},
+}
+
+func init() {
+	restoreCmd.Flags().StringVar(&restoreOpts.Folder, "folder", "Benchmarks", "Grafana folder to restore into")
+	restoreCmd.Flags().StringVar(&restoreOpts.RepoDir, "repo-dir", "./dashboards-backup", "git working tree to read dashboards from")
+
+	rootCmd.AddCommand(restoreCmd)
+}
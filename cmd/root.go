@@ -0,0 +1,29 @@
+// Package cmd wires up the grabana-upsert CLI: apply, backup and restore
+// subcommands sharing a common Grafana connection.
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	grafanaHost  string
+	grafanaToken string
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "grabana-upsert",
+	Short: "Manage the benchmark matrix's Grafana dashboards as code",
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&grafanaHost, "grafana-host", os.Getenv("GRAFANA_HOST"), "Grafana base URL (default: $GRAFANA_HOST)")
+	rootCmd.PersistentFlags().StringVar(&grafanaToken, "grafana-token", os.Getenv("GRAFANA_API_TOKEN"), "Grafana API token (default: $GRAFANA_API_TOKEN)")
+}
+
+// Execute runs the root command.
+func Execute() error {
+	return rootCmd.Execute()
+}
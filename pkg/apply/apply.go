@@ -0,0 +1,325 @@
+// Package apply discovers dashboard YAML sources, templates them, and
+// upserts them into Grafana, reconciling drift as it goes.
+package apply
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/K-Phoen/grabana"
+	"github.com/K-Phoen/grabana/decoder"
+
+	"github.com/Hades32/temporal-benchmark-matrix/pkg/datasources"
+	"github.com/Hades32/temporal-benchmark-matrix/pkg/loader"
+	"github.com/Hades32/temporal-benchmark-matrix/pkg/reconcile"
+)
+
+const defaultFolder = "Benchmarks"
+
+// decodeMu guards decoder.UnmarshalYAML, which is not safe for concurrent
+// use (see upsertOne).
+var decodeMu sync.Mutex
+
+// Options configures a single apply run.
+type Options struct {
+	DashboardsDir   string
+	ValuesPath      string
+	ManifestPath    string
+	DatasourcesPath string
+	SecretsDir      string
+	Concurrency     int
+	DryRun          bool
+	Prune           bool
+	FailOnDrift     bool
+	GrafanaHost     string
+	GrafanaToken    string
+}
+
+// Result is one dashboard file's outcome, part of the JSON summary emitted
+// for CI consumption.
+type Result struct {
+	Path         string `json:"path"`
+	Folder       string `json:"folder"`
+	DashboardUID string `json:"dashboard_uid,omitempty"`
+	Status       string `json:"status"` // created, updated, skipped, failed
+	Error        string `json:"error,omitempty"`
+}
+
+// Summary is the structured output of a Run, ready to be marshalled to JSON.
+type Summary struct {
+	Results []Result `json:"results"`
+}
+
+// Run discovers every dashboard under opts.DashboardsDir, upserts whichever
+// ones changed since the last apply, and reconciles drift per folder
+// afterwards. It returns the per-file summary regardless of whether
+// reconciliation ultimately fails opts.FailOnDrift, so callers can always
+// print it.
+func Run(ctx context.Context, opts Options) (Summary, error) {
+	paths, err := loader.Discover(opts.DashboardsDir, opts.ValuesPath, opts.DatasourcesPath)
+	if err != nil {
+		return Summary{}, fmt.Errorf("could not discover dashboards: %w", err)
+	}
+
+	values, err := loader.LoadValues(opts.ValuesPath)
+	if err != nil {
+		return Summary{}, fmt.Errorf("could not load values: %w", err)
+	}
+
+	httpClient := &http.Client{}
+	client := grabana.NewClient(httpClient, opts.GrafanaHost, grabana.WithAPIToken(opts.GrafanaToken))
+	reconciler := reconcile.New(httpClient, opts.GrafanaHost, opts.GrafanaToken)
+
+	if !opts.DryRun && opts.DatasourcesPath != "" {
+		dsConfig, err := datasources.Load(opts.DatasourcesPath)
+		if err != nil {
+			return Summary{}, fmt.Errorf("could not load datasources: %w", err)
+		}
+		if len(dsConfig.Datasources) > 0 {
+			provisioner := datasources.New(httpClient, opts.GrafanaHost, opts.GrafanaToken, opts.SecretsDir)
+			uids, err := provisioner.Provision(ctx, dsConfig)
+			if err != nil {
+				return Summary{}, fmt.Errorf("could not provision datasources: %w", err)
+			}
+			values["Datasources"] = uids
+		}
+	}
+
+	manifest, err := reconcile.LoadManifest(opts.ManifestPath)
+	if err != nil {
+		return Summary{}, fmt.Errorf("could not load manifest: %w", err)
+	}
+
+	// Snapshot the manifest's source hashes before anything in this run can
+	// mutate them, so the Changed report below reflects what was true when
+	// the run started rather than what upsertOne just wrote back.
+	previousSourceHashes := make(map[string]string, len(manifest.Entries))
+	for _, e := range manifest.Entries {
+		previousSourceHashes[e.SourcePath] = e.SourceHash
+	}
+
+	var (
+		folderMu sync.Mutex
+		folders  = make(map[string]*grabana.Folder)
+
+		manifestMu sync.Mutex
+		results    = make([]Result, len(paths))
+	)
+
+	// findOrCreateFolder creates the folder in Grafana if it doesn't exist
+	// yet; only used outside dry-run, since dry-run must never mutate Grafana.
+	findOrCreateFolder := func(name string) (*grabana.Folder, error) {
+		folderMu.Lock()
+		defer folderMu.Unlock()
+		if f, ok := folders[name]; ok {
+			return f, nil
+		}
+		f, err := client.FindOrCreateFolder(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		folders[name] = f
+		return f, nil
+	}
+
+	// findFolder looks up a folder without creating it, returning (nil, nil)
+	// if it doesn't exist yet. Used for dry-run, where reporting would-create
+	// for a dashboard in a not-yet-existing folder must not create that folder.
+	findFolder := func(name string) (*grabana.Folder, error) {
+		folderMu.Lock()
+		defer folderMu.Unlock()
+		if f, ok := folders[name]; ok {
+			return f, nil
+		}
+		f, err := client.GetFolderByTitle(ctx, name)
+		if err != nil {
+			if errors.Is(err, grabana.ErrFolderNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		folders[name] = f
+		return f, nil
+	}
+
+	resolveFolder := findOrCreateFolder
+	if opts.DryRun {
+		resolveFolder = findFolder
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = upsertOne(ctx, client, reconciler, resolveFolder, manifest, &manifestMu, paths[i], values, opts.DryRun)
+			}
+		}()
+	}
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if !opts.DryRun {
+		if err := manifest.Save(opts.ManifestPath); err != nil {
+			return Summary{Results: results}, fmt.Errorf("could not save manifest: %w", err)
+		}
+	}
+
+	currentSourceHashes := make(map[string]string, len(results))
+	for i, r := range results {
+		if r.Status != "failed" {
+			if file, err := loader.Load(paths[i], values); err == nil {
+				currentSourceHashes[r.Path] = reconcile.HashContent(file.Content)
+			}
+		}
+	}
+
+	for name, folder := range folders {
+		report, err := reconciler.Reconcile(ctx, folder.ID, manifest, previousSourceHashes, currentSourceHashes)
+		if err != nil {
+			return Summary{Results: results}, fmt.Errorf("could not reconcile folder %s: %w", name, err)
+		}
+		if !report.Dirty() {
+			continue
+		}
+
+		if opts.Prune && !opts.DryRun {
+			for _, uid := range report.Orphans {
+				if err := reconciler.DeleteDashboard(ctx, uid); err != nil {
+					return Summary{Results: results}, fmt.Errorf("prune orphan dashboard %s in folder %s: %w", uid, name, err)
+				}
+			}
+		}
+
+		if opts.FailOnDrift {
+			return Summary{Results: results}, fmt.Errorf("drift detected in folder %s: %d orphan(s), %d drifted, %d changed source(s)",
+				name, len(report.Orphans), len(report.Drifted), len(report.Changed))
+		}
+	}
+
+	return Summary{Results: results}, nil
+}
+
+// upsertOne loads and templates a single dashboard file, skipping the rest
+// entirely if its content hash hasn't changed since the last apply. In
+// dryRun mode it stops after resolving (never creating) the target folder,
+// without calling UpsertDashboard or writing anything back to the manifest.
+// resolveFolder is findOrCreateFolder outside dry-run, or a lookup-only
+// variant that returns (nil, nil) for a folder that doesn't exist yet.
+func upsertOne(
+	ctx context.Context,
+	client *grabana.Client,
+	reconciler *reconcile.Reconciler,
+	resolveFolder func(string) (*grabana.Folder, error),
+	manifest *reconcile.Manifest,
+	manifestMu *sync.Mutex,
+	path string,
+	values map[string]interface{},
+	dryRun bool,
+) Result {
+	file, err := loader.Load(path, values)
+	if err != nil {
+		return Result{Path: path, Status: "failed", Error: err.Error()}
+	}
+
+	folderName := file.Folder
+	if folderName == "" {
+		folderName = defaultFolder
+	}
+	res := Result{Path: path, Folder: folderName}
+
+	manifestMu.Lock()
+	prev, hadPrev := manifest.BySourcePath(path)
+	manifestMu.Unlock()
+
+	hash := reconcile.HashContent(file.Content)
+	if hadPrev && prev.SourceHash == hash {
+		res.Status = "skipped"
+		res.DashboardUID = prev.DashboardUID
+		return res
+	}
+
+	// decoder.UnmarshalYAML isn't safe for concurrent use: it bottoms out in
+	// grabana/sdk.NewBoard, which increments a package-level board ID with no
+	// synchronization. Serialize just this parse step; everything else in
+	// upsertOne (network calls) still runs concurrently across workers.
+	decodeMu.Lock()
+	dashboard, err := decoder.UnmarshalYAML(bytes.NewBuffer(file.Content))
+	decodeMu.Unlock()
+	if err != nil {
+		res.Status = "failed"
+		res.Error = fmt.Sprintf("parse: %v", err)
+		return res
+	}
+
+	folder, err := resolveFolder(folderName)
+	if err != nil {
+		res.Status = "failed"
+		res.Error = fmt.Sprintf("resolve folder: %v", err)
+		return res
+	}
+
+	if dryRun {
+		if hadPrev {
+			res.Status = "would-update"
+		} else {
+			res.Status = "would-create"
+		}
+		res.DashboardUID = prev.DashboardUID
+		return res
+	}
+
+	// Outside dry-run, resolveFolder is findOrCreateFolder, which never
+	// returns (nil, nil); folder is always set here.
+	board, err := client.UpsertDashboard(ctx, folder, dashboard)
+	if err != nil {
+		res.Status = "failed"
+		res.Error = fmt.Sprintf("upsert: %v", err)
+		return res
+	}
+	res.DashboardUID = board.UID
+
+	appliedHash, err := reconciler.FetchDashboardHash(ctx, board.UID)
+	if err != nil {
+		res.Status = "failed"
+		res.Error = fmt.Sprintf("read back applied dashboard: %v", err)
+		return res
+	}
+
+	manifestMu.Lock()
+	manifest.Put(reconcile.Entry{
+		SourcePath:   path,
+		SourceHash:   hash,
+		FolderUID:    folder.UID,
+		DashboardUID: board.UID,
+		AppliedHash:  appliedHash,
+	})
+	manifestMu.Unlock()
+
+	if hadPrev {
+		res.Status = "updated"
+	} else {
+		res.Status = "created"
+	}
+	return res
+}
+
+// MarshalSummary renders a Summary as indented JSON for CI consumption.
+func MarshalSummary(s Summary) ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
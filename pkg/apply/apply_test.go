@@ -0,0 +1,242 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// stubGrafana fakes just enough of the Grafana HTTP API for Run to exercise
+// folder lookup/creation and dashboard upsert/read-back, while counting every
+// mutating request so tests can assert dry-run issued none.
+type stubGrafana struct {
+	mu         sync.Mutex
+	folders    map[string]uint // title -> id
+	nextID     uint
+	dashboards map[string]map[string]interface{} // uid -> dashboard body
+	nextUID    int
+	mutations  int32
+}
+
+func newStubGrafana() *stubGrafana {
+	return &stubGrafana{
+		folders:    make(map[string]uint),
+		dashboards: make(map[string]map[string]interface{}),
+	}
+}
+
+func (s *stubGrafana) mutationCount() int {
+	return int(atomic.LoadInt32(&s.mutations))
+}
+
+func (s *stubGrafana) start(t *testing.T) string {
+	t.Helper()
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/search", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("type") == "dash-folder" {
+			title := r.URL.Query().Get("query")
+			s.mu.Lock()
+			id, ok := s.folders[title]
+			s.mu.Unlock()
+			if !ok {
+				_ = json.NewEncoder(w).Encode([]map[string]interface{}{})
+				return
+			}
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": id, "uid": fmt.Sprintf("folder-%d", id), "title": title},
+			})
+			return
+		}
+		// Dashboard-in-folder listing, used by reconcile.Reconcile; this test
+		// suite doesn't exercise orphan/drift detection, so report none.
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{})
+	})
+
+	mux.HandleFunc("/api/folders", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.mutations, 1)
+		var body struct {
+			Title string `json:"title"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		s.mu.Lock()
+		s.nextID++
+		id := s.nextID
+		s.folders[body.Title] = id
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": id, "uid": fmt.Sprintf("folder-%d", id), "title": body.Title})
+	})
+
+	mux.HandleFunc("/api/dashboards/db", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.mutations, 1)
+		var body struct {
+			Dashboard map[string]interface{} `json:"dashboard"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		s.mu.Lock()
+		s.nextUID++
+		uid := fmt.Sprintf("dash-%d", s.nextUID)
+		body.Dashboard["uid"] = uid
+		s.dashboards[uid] = body.Dashboard
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"uid": uid})
+	})
+
+	mux.HandleFunc("/api/dashboards/uid/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			atomic.AddInt32(&s.mutations, 1)
+		}
+		uid := strings.TrimPrefix(r.URL.Path, "/api/dashboards/uid/")
+
+		s.mu.Lock()
+		dash, ok := s.dashboards[uid]
+		if r.Method == http.MethodDelete {
+			delete(s.dashboards, uid)
+		}
+		s.mu.Unlock()
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"dashboard": dash})
+	})
+
+	mux.HandleFunc("/api/ruler/grafana/api/v1/rules", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func writeDashboard(t *testing.T, dir, name, folder string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	content := fmt.Sprintf("# grabana:folder: %s\ntitle: %s\n", folder, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestRunDryRunMakesNoMutatingCalls(t *testing.T) {
+	stub := newStubGrafana()
+	dir := t.TempDir()
+	writeDashboard(t, dir, "stack.yaml", "Temporal Benchmarks")
+
+	opts := Options{
+		DashboardsDir: dir,
+		ValuesPath:    filepath.Join(dir, "values.yaml"),
+		ManifestPath:  filepath.Join(dir, ".manifest.json"),
+		Concurrency:   2,
+		DryRun:        true,
+		GrafanaHost:   stub.start(t),
+		GrafanaToken:  "token",
+	}
+
+	summary, err := Run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(summary.Results) != 1 || summary.Results[0].Status != "would-create" {
+		t.Fatalf("Results = %+v, want one would-create result", summary.Results)
+	}
+	if got := stub.mutationCount(); got != 0 {
+		t.Errorf("dry-run made %d mutating call(s), want 0", got)
+	}
+	if _, err := os.Stat(opts.ManifestPath); !os.IsNotExist(err) {
+		t.Errorf("dry-run should not write a manifest, but %s exists", opts.ManifestPath)
+	}
+}
+
+func TestRunSkipsUnchangedSource(t *testing.T) {
+	stub := newStubGrafana()
+	dir := t.TempDir()
+	writeDashboard(t, dir, "stack.yaml", "Temporal Benchmarks")
+
+	opts := Options{
+		DashboardsDir: dir,
+		ValuesPath:    filepath.Join(dir, "values.yaml"),
+		ManifestPath:  filepath.Join(dir, ".manifest.json"),
+		Concurrency:   1,
+		GrafanaHost:   stub.start(t),
+		GrafanaToken:  "token",
+	}
+
+	first, err := Run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("first Run: %v", err)
+	}
+	if len(first.Results) != 1 || first.Results[0].Status != "created" {
+		t.Fatalf("first Results = %+v, want one created result", first.Results)
+	}
+	afterFirst := stub.mutationCount()
+
+	second, err := Run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+	if len(second.Results) != 1 || second.Results[0].Status != "skipped" {
+		t.Fatalf("second Results = %+v, want one skipped result", second.Results)
+	}
+	if got := stub.mutationCount(); got != afterFirst {
+		t.Errorf("second run made %d additional mutating call(s), want 0 (source unchanged)", got-afterFirst)
+	}
+}
+
+func TestRunConcurrentUpsertSucceedsForEveryFile(t *testing.T) {
+	stub := newStubGrafana()
+	dir := t.TempDir()
+
+	const fileCount = 8
+	for i := 0; i < fileCount; i++ {
+		writeDashboard(t, dir, fmt.Sprintf("dash-%d.yaml", i), fmt.Sprintf("Folder %d", i%3))
+	}
+
+	opts := Options{
+		DashboardsDir: dir,
+		ValuesPath:    filepath.Join(dir, "values.yaml"),
+		ManifestPath:  filepath.Join(dir, ".manifest.json"),
+		Concurrency:   4,
+		GrafanaHost:   stub.start(t),
+		GrafanaToken:  "token",
+	}
+
+	summary, err := Run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(summary.Results) != fileCount {
+		t.Fatalf("got %d results, want %d", len(summary.Results), fileCount)
+	}
+
+	seenUIDs := make(map[string]bool, fileCount)
+	for _, r := range summary.Results {
+		if r.Status != "created" {
+			t.Errorf("result for %s: status = %q, want created (error: %s)", r.Path, r.Status, r.Error)
+		}
+		if r.DashboardUID == "" || seenUIDs[r.DashboardUID] {
+			t.Errorf("result for %s: unexpected/duplicate dashboard UID %q", r.Path, r.DashboardUID)
+		}
+		seenUIDs[r.DashboardUID] = true
+	}
+}
@@ -0,0 +1,161 @@
+// Package backup pulls dashboards out of Grafana into a git working tree
+// and pushes raw dashboard JSON back in, giving the benchmark matrix a
+// reproducible dashboard history and a rollback path.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/K-Phoen/grabana"
+
+	"github.com/Hades32/temporal-benchmark-matrix/pkg/reconcile"
+)
+
+// Options configures a single backup run.
+type Options struct {
+	GrafanaHost  string
+	GrafanaToken string
+	Folder       string
+	RepoDir      string
+	Commit       bool
+	Push         bool
+	Message      string
+}
+
+// Result records one dashboard written into the repo.
+type Result struct {
+	DashboardUID string `json:"dashboard_uid"`
+	Path         string `json:"path"`
+}
+
+// Backup fetches every dashboard in opts.Folder, normalizes it, and writes
+// it into opts.RepoDir, optionally committing and pushing.
+func Backup(ctx context.Context, opts Options) ([]Result, error) {
+	httpClient := &http.Client{}
+	client := grabana.NewClient(httpClient, opts.GrafanaHost, grabana.WithAPIToken(opts.GrafanaToken))
+	reconciler := reconcile.New(httpClient, opts.GrafanaHost, opts.GrafanaToken)
+
+	folder, err := client.FindOrCreateFolder(ctx, opts.Folder)
+	if err != nil {
+		return nil, fmt.Errorf("find or create folder: %w", err)
+	}
+
+	dashboards, err := reconciler.ListFolderDashboards(ctx, folder.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list folder %s: %w", opts.Folder, err)
+	}
+
+	if err := os.MkdirAll(opts.RepoDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create repo dir %s: %w", opts.RepoDir, err)
+	}
+
+	var results []Result
+	for _, d := range dashboards {
+		raw, err := reconciler.FetchDashboardJSON(ctx, d.UID)
+		if err != nil {
+			return results, fmt.Errorf("fetch dashboard %s: %w", d.UID, err)
+		}
+		if raw == nil {
+			continue // disappeared between the search and the fetch
+		}
+
+		normalized, err := normalize(raw)
+		if err != nil {
+			return results, fmt.Errorf("normalize dashboard %s: %w", d.UID, err)
+		}
+
+		path := filepath.Join(opts.RepoDir, slug(d.Title)+"-"+d.UID+".json")
+		if err := os.WriteFile(path, normalized, 0o644); err != nil {
+			return results, fmt.Errorf("write %s: %w", path, err)
+		}
+		results = append(results, Result{DashboardUID: d.UID, Path: path})
+	}
+
+	if opts.Commit {
+		message := opts.Message
+		if message == "" {
+			message = fmt.Sprintf("backup: %d dashboard(s) from %s", len(results), opts.Folder)
+		}
+		if err := runGit(opts.RepoDir, "add", "-A"); err != nil {
+			return results, err
+		}
+		if err := runGit(opts.RepoDir, "commit", "-m", message); err != nil {
+			return results, err
+		}
+	}
+	if opts.Push {
+		if err := runGit(opts.RepoDir, "push"); err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+// normalize strips the fields that churn on every save (id, version,
+// iteration) and datasource UIDs that are only valid on the Grafana
+// instance we pulled from, so the committed JSON is stable across restores.
+func normalize(raw json.RawMessage) ([]byte, error) {
+	var envelope struct {
+		Dashboard map[string]interface{} `json:"dashboard"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, fmt.Errorf("unmarshal dashboard envelope: %w", err)
+	}
+
+	dashboard := envelope.Dashboard
+	delete(dashboard, "id")
+	delete(dashboard, "version")
+	delete(dashboard, "iteration")
+	stripDatasourceUIDs(dashboard)
+
+	return json.MarshalIndent(dashboard, "", "  ")
+}
+
+// stripDatasourceUIDs blanks out every "uid" under a "datasource" object it
+// finds, walking arbitrarily nested panels/targets/templating variables.
+func stripDatasourceUIDs(node interface{}) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if key == "datasource" {
+				if ds, ok := val.(map[string]interface{}); ok {
+					if _, ok := ds["uid"]; ok {
+						ds["uid"] = ""
+					}
+				}
+			}
+			stripDatasourceUIDs(val)
+		}
+	case []interface{}:
+		for _, item := range v {
+			stripDatasourceUIDs(item)
+		}
+	}
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slug turns a dashboard title into a filesystem- and git-friendly name.
+func slug(title string) string {
+	s := nonSlugChars.ReplaceAllString(strings.ToLower(title), "-")
+	return strings.Trim(s, "-")
+}
+
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package backup
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSlug(t *testing.T) {
+	cases := []struct {
+		title string
+		want  string
+	}{
+		{"Temporal Worker Latency", "temporal-worker-latency"},
+		{"  Leading/Trailing Punctuation! ", "leading-trailing-punctuation"},
+		{"Already-slugged", "already-slugged"},
+	}
+	for _, tc := range cases {
+		if got := slug(tc.title); got != tc.want {
+			t.Errorf("slug(%q) = %q, want %q", tc.title, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizeStripsChurnAndDatasourceUIDs(t *testing.T) {
+	raw := json.RawMessage(`{
+		"dashboard": {
+			"id": 42,
+			"version": 7,
+			"iteration": 1690000000000,
+			"title": "Example",
+			"panels": [
+				{
+					"datasource": {"type": "prometheus", "uid": "live-uid-123"},
+					"targets": [
+						{"datasource": {"type": "prometheus", "uid": "live-uid-123"}}
+					]
+				}
+			]
+		}
+	}`)
+
+	out, err := normalize(raw)
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+
+	var dashboard map[string]interface{}
+	if err := json.Unmarshal(out, &dashboard); err != nil {
+		t.Fatalf("unmarshal normalized output: %v", err)
+	}
+
+	for _, field := range []string{"id", "version", "iteration"} {
+		if _, ok := dashboard[field]; ok {
+			t.Errorf("expected %q to be stripped, got %v", field, dashboard[field])
+		}
+	}
+
+	panels, ok := dashboard["panels"].([]interface{})
+	if !ok || len(panels) != 1 {
+		t.Fatalf("expected panels to survive normalization, got %v", dashboard["panels"])
+	}
+	panel := panels[0].(map[string]interface{})
+	ds := panel["datasource"].(map[string]interface{})
+	if ds["uid"] != "" {
+		t.Errorf("expected panel datasource uid to be blanked, got %v", ds["uid"])
+	}
+	target := panel["targets"].([]interface{})[0].(map[string]interface{})
+	tds := target["datasource"].(map[string]interface{})
+	if tds["uid"] != "" {
+		t.Errorf("expected nested target datasource uid to be blanked, got %v", tds["uid"])
+	}
+}
@@ -0,0 +1,65 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/K-Phoen/grabana"
+
+	"github.com/Hades32/temporal-benchmark-matrix/pkg/reconcile"
+)
+
+// RestoreOptions configures a single restore run.
+type RestoreOptions struct {
+	GrafanaHost  string
+	GrafanaToken string
+	Folder       string
+	RepoDir      string
+}
+
+// Restore reads every normalized dashboard JSON file under opts.RepoDir and
+// upserts it back into opts.Folder.
+func Restore(ctx context.Context, opts RestoreOptions) ([]Result, error) {
+	httpClient := &http.Client{}
+	client := grabana.NewClient(httpClient, opts.GrafanaHost, grabana.WithAPIToken(opts.GrafanaToken))
+	reconciler := reconcile.New(httpClient, opts.GrafanaHost, opts.GrafanaToken)
+
+	folder, err := client.FindOrCreateFolder(ctx, opts.Folder)
+	if err != nil {
+		return nil, fmt.Errorf("find or create folder: %w", err)
+	}
+
+	entries, err := os.ReadDir(opts.RepoDir)
+	if err != nil {
+		return nil, fmt.Errorf("read repo dir %s: %w", opts.RepoDir, err)
+	}
+
+	var results []Result
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(opts.RepoDir, entry.Name())
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return results, fmt.Errorf("read %s: %w", path, err)
+		}
+
+		var dashboard map[string]interface{}
+		if err := json.Unmarshal(raw, &dashboard); err != nil {
+			return results, fmt.Errorf("parse %s: %w", path, err)
+		}
+
+		uid, err := reconciler.PutDashboard(ctx, folder.ID, dashboard)
+		if err != nil {
+			return results, fmt.Errorf("restore %s: %w", path, err)
+		}
+		results = append(results, Result{DashboardUID: uid, Path: path})
+	}
+	return results, nil
+}
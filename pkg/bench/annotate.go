@@ -0,0 +1,56 @@
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Annotation marks one benchmark run on a dashboard as a Grafana region
+// annotation spanning Start to End.
+type Annotation struct {
+	DashboardUID string
+	Start        time.Time
+	End          time.Time
+	Text         string
+	Tags         []string
+}
+
+// CreateAnnotation posts to Grafana's /api/annotations endpoint.
+func CreateAnnotation(ctx context.Context, httpClient *http.Client, host, token string, a Annotation) error {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"dashboardUID": a.DashboardUID,
+		"time":         a.Start.UnixMilli(),
+		"timeEnd":      a.End.UnixMilli(),
+		"tags":         a.Tags,
+		"text":         a.Text,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal annotation: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, host+"/api/annotations", strings.NewReader(string(data)))
+	if err != nil {
+		return fmt.Errorf("build annotation request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("create annotation: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("create annotation: unexpected status %s", resp.Status)
+	}
+	return nil
+}
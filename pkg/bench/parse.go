@@ -0,0 +1,172 @@
+// Package bench ingests `go test -bench` output, pushes it to a Prometheus
+// pushgateway, and annotates the benchmark matrix's dashboards so spikes can
+// be correlated back to a specific run.
+package bench
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Result is one parsed `BenchmarkXxx-N  iters  ns/op  B/op  allocs/op` line.
+type Result struct {
+	Name        string
+	Procs       int
+	Iterations  int64
+	NsPerOp     float64
+	BytesPerOp  int64
+	AllocsPerOp int64
+}
+
+var benchLine = regexp.MustCompile(`^(Benchmark\S+)\s+(\d+)\s+(.+)$`)
+
+// ParseGoBenchOutput parses the benchmark lines out of `go test -bench`
+// output, ignoring any build logs or PASS/ok lines interleaved with them.
+func ParseGoBenchOutput(r io.Reader) ([]Result, error) {
+	var results []Result
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		m := benchLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		iterations, err := strconv.ParseInt(m[2], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		name, procs := splitNameProcs(m[1])
+		result := Result{Name: name, Procs: procs, Iterations: iterations}
+
+		fields := strings.Fields(m[3])
+		for i := 0; i+1 < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				continue
+			}
+			switch fields[i+1] {
+			case "ns/op":
+				result.NsPerOp = value
+			case "B/op":
+				result.BytesPerOp = int64(value)
+			case "allocs/op":
+				result.AllocsPerOp = int64(value)
+			}
+		}
+
+		results = append(results, result)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan benchmark output: %w", err)
+	}
+	return results, nil
+}
+
+var (
+	benchstatValue = regexp.MustCompile(`^([0-9.]+)([a-zµ]*)`)
+	siPrefixes     = map[string]float64{
+		"p": 1e-12, "n": 1e-9, "µ": 1e-6, "u": 1e-6, "m": 1e-3, "": 1,
+		"k": 1e3, "M": 1e6, "G": 1e9,
+	}
+)
+
+// ParseBenchstatCSV parses a single-metric benchstat "-format csv" report
+// (produced by `benchstat -format csv old.txt`), such as:
+//
+//	goos: linux
+//	goarch: amd64
+//	pkg: example.com/bench
+//	,sec/op
+//	BenchmarkFoo-8,123.4n ± 2%
+//	BenchmarkBar-8,45.6µ
+//	geomean,67.8n
+//
+// It only understands the sec/op metric, which is what a plain `benchstat
+// old.txt` run reports by default. B/op and allocs/op columns,
+// multi-configuration comparisons (old vs new), and the geomean summary row
+// are out of scope and are skipped rather than guessed at.
+func ParseBenchstatCSV(r io.Reader) ([]Result, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	var (
+		results   []Result
+		metric    string
+		sawHeader bool
+	)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse benchstat csv: %w", err)
+		}
+		if len(record) < 2 {
+			continue
+		}
+
+		if !sawHeader {
+			if record[0] == "" {
+				metric = record[1]
+				sawHeader = true
+			}
+			continue
+		}
+
+		name := record[0]
+		if name == "" || name == "geomean" || metric != "sec/op" {
+			continue
+		}
+
+		value := strings.TrimSpace(strings.SplitN(record[1], "±", 2)[0])
+		nsPerOp, err := parseBenchstatSeconds(value)
+		if err != nil {
+			continue
+		}
+
+		benchName, procs := splitNameProcs(name)
+		results = append(results, Result{Name: benchName, Procs: procs, NsPerOp: nsPerOp})
+	}
+	return results, nil
+}
+
+// parseBenchstatSeconds converts a benchstat engineering-notation duration
+// such as "123.4n" (nanoseconds) or "45.6µ" (microseconds) -- always
+// relative to seconds -- into nanoseconds.
+func parseBenchstatSeconds(s string) (float64, error) {
+	m := benchstatValue.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("parse benchstat value %q", s)
+	}
+	value, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, err
+	}
+	mult, ok := siPrefixes[m[2]]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit prefix %q in %q", m[2], s)
+	}
+	return value * mult * 1e9, nil
+}
+
+// splitNameProcs splits "BenchmarkFoo-8" into ("BenchmarkFoo", 8).
+func splitNameProcs(nameAndProcs string) (string, int) {
+	idx := strings.LastIndex(nameAndProcs, "-")
+	if idx < 0 {
+		return nameAndProcs, 0
+	}
+	procs, err := strconv.Atoi(nameAndProcs[idx+1:])
+	if err != nil {
+		return nameAndProcs, 0
+	}
+	return nameAndProcs[:idx], procs
+}
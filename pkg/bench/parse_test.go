@@ -0,0 +1,118 @@
+package bench
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// approxEqual reports whether got and want are within float64 rounding error
+// of each other; ParseBenchstatCSV's unit conversion multiplies by powers of
+// ten that aren't exactly representable, so exact equality isn't meaningful.
+func approxEqual(got, want float64) bool {
+	return math.Abs(got-want) < 1e-6*math.Max(1, math.Abs(want))
+}
+
+func TestParseGoBenchOutput(t *testing.T) {
+	input := `goos: linux
+goarch: amd64
+pkg: github.com/Hades32/temporal-benchmark-matrix
+cpu: Intel(R) Xeon(R)
+BenchmarkWorkflowStart-8       1234    912345 ns/op    2048 B/op    17 allocs/op
+BenchmarkActivityExec-4        5678    341200 ns/op     512 B/op     4 allocs/op
+PASS
+ok      github.com/Hades32/temporal-benchmark-matrix    3.456s
+`
+
+	results, err := ParseGoBenchOutput(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseGoBenchOutput: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2: %+v", len(results), results)
+	}
+
+	want := Result{Name: "BenchmarkWorkflowStart", Procs: 8, Iterations: 1234, NsPerOp: 912345, BytesPerOp: 2048, AllocsPerOp: 17}
+	if results[0] != want {
+		t.Errorf("results[0] = %+v, want %+v", results[0], want)
+	}
+
+	want = Result{Name: "BenchmarkActivityExec", Procs: 4, Iterations: 5678, NsPerOp: 341200, BytesPerOp: 512, AllocsPerOp: 4}
+	if results[1] != want {
+		t.Errorf("results[1] = %+v, want %+v", results[1], want)
+	}
+}
+
+func TestParseGoBenchOutputNoBenchmarks(t *testing.T) {
+	results, err := ParseGoBenchOutput(strings.NewReader("PASS\nok  	example.com/x	0.004s\n"))
+	if err != nil {
+		t.Fatalf("ParseGoBenchOutput: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("got %d results, want 0", len(results))
+	}
+}
+
+func TestSplitNameProcs(t *testing.T) {
+	cases := []struct {
+		in        string
+		wantName  string
+		wantProcs int
+	}{
+		{"BenchmarkFoo-8", "BenchmarkFoo", 8},
+		{"BenchmarkFoo", "BenchmarkFoo", 0},
+		{"BenchmarkFoo-bar", "BenchmarkFoo-bar", 0},
+	}
+	for _, tc := range cases {
+		name, procs := splitNameProcs(tc.in)
+		if name != tc.wantName || procs != tc.wantProcs {
+			t.Errorf("splitNameProcs(%q) = (%q, %d), want (%q, %d)", tc.in, name, procs, tc.wantName, tc.wantProcs)
+		}
+	}
+}
+
+func TestParseBenchstatCSV(t *testing.T) {
+	input := `goos: linux
+goarch: amd64
+pkg: github.com/Hades32/temporal-benchmark-matrix
+,sec/op
+BenchmarkWorkflowStart-8,912.3µ ± 2%
+BenchmarkActivityExec-4,341.2n
+geomean,558.1n
+`
+
+	results, err := ParseBenchstatCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseBenchstatCSV: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2: %+v", len(results), results)
+	}
+
+	if results[0].Name != "BenchmarkWorkflowStart" || results[0].Procs != 8 {
+		t.Errorf("results[0] name/procs = %q/%d, want BenchmarkWorkflowStart/8", results[0].Name, results[0].Procs)
+	}
+	if got, want := results[0].NsPerOp, 912300.0; !approxEqual(got, want) {
+		t.Errorf("results[0].NsPerOp = %v, want %v", got, want)
+	}
+
+	if results[1].Name != "BenchmarkActivityExec" || results[1].Procs != 4 {
+		t.Errorf("results[1] name/procs = %q/%d, want BenchmarkActivityExec/4", results[1].Name, results[1].Procs)
+	}
+	if got, want := results[1].NsPerOp, 341.2; !approxEqual(got, want) {
+		t.Errorf("results[1].NsPerOp = %v, want %v", got, want)
+	}
+}
+
+func TestParseBenchstatCSVSkipsNonSecOpMetric(t *testing.T) {
+	input := `,B/op
+BenchmarkFoo-8,128
+`
+	results, err := ParseBenchstatCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseBenchstatCSV: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("got %d results, want 0 (B/op is out of scope)", len(results))
+	}
+}
@@ -0,0 +1,91 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// PushOptions configures a push of benchmark results to a Prometheus
+// pushgateway.
+type PushOptions struct {
+	GatewayURL string
+	Job        string
+	Labels     map[string]string // attached to every series, e.g. run_id, commit
+}
+
+// Push writes results to the pushgateway in Prometheus text exposition
+// format, grouped under job/<job> per the pushgateway's push API.
+func Push(ctx context.Context, httpClient *http.Client, opts PushOptions, results []Result) error {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	var body strings.Builder
+	for _, r := range results {
+		labels := formatLabels(mergeLabels(opts.Labels, map[string]string{
+			"benchmark": r.Name,
+			"procs":     fmt.Sprint(r.Procs),
+		}))
+		fmt.Fprintf(&body, "benchmark_ns_per_op%s %g\n", labels, r.NsPerOp)
+		fmt.Fprintf(&body, "benchmark_bytes_per_op%s %d\n", labels, r.BytesPerOp)
+		fmt.Fprintf(&body, "benchmark_allocs_per_op%s %d\n", labels, r.AllocsPerOp)
+		fmt.Fprintf(&body, "benchmark_iterations%s %d\n", labels, r.Iterations)
+	}
+
+	url := fmt.Sprintf("%s/metrics/job/%s", strings.TrimRight(opts.GatewayURL, "/"), opts.Job)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(body.String()))
+	if err != nil {
+		return fmt.Errorf("build pushgateway request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("push to pushgateway: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("push to pushgateway: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func mergeLabels(sets ...map[string]string) map[string]string {
+	out := map[string]string{}
+	for _, set := range sets {
+		for k, v := range set {
+			if v != "" {
+				out[k] = v
+			}
+		}
+	}
+	return out
+}
+
+// formatLabels renders labels in Prometheus text-exposition-format order,
+// i.e. sorted by key so the same input always produces the same line.
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=%q", k, labels[k])
+	}
+	b.WriteByte('}')
+	return b.String()
+}
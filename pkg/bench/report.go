@@ -0,0 +1,112 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/K-Phoen/grabana"
+
+	"github.com/Hades32/temporal-benchmark-matrix/pkg/reconcile"
+)
+
+// ReportOptions configures a single `report` run: parse bench output, push
+// it to a pushgateway, and annotate every dashboard in Folder.
+type ReportOptions struct {
+	InputPath          string
+	RunID              string
+	Commit             string
+	TemporalVersion    string
+	WorkflowSDKVersion string
+	PushgatewayURL     string
+	Folder             string
+	Format             string // "gotest" (default) or "benchstat-csv"
+	Start              time.Time
+	End                time.Time
+	GrafanaHost        string
+	GrafanaToken       string
+}
+
+// Report parses opts.InputPath, optionally pushes the series to a
+// pushgateway, and creates a region annotation on every dashboard in
+// opts.Folder marking this run.
+func Report(ctx context.Context, opts ReportOptions) error {
+	f, err := os.Open(opts.InputPath)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", opts.InputPath, err)
+	}
+	defer f.Close()
+
+	var results []Result
+	switch opts.Format {
+	case "", "gotest":
+		results, err = ParseGoBenchOutput(f)
+	case "benchstat-csv":
+		results, err = ParseBenchstatCSV(f)
+	default:
+		return fmt.Errorf("unknown --format %q (want \"gotest\" or \"benchstat-csv\")", opts.Format)
+	}
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", opts.InputPath, err)
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("no benchmark results found in %s", opts.InputPath)
+	}
+
+	httpClient := &http.Client{}
+
+	if opts.PushgatewayURL != "" {
+		err := Push(ctx, httpClient, PushOptions{
+			GatewayURL: opts.PushgatewayURL,
+			Job:        "temporal_benchmark_matrix",
+			Labels: map[string]string{
+				"run_id":           opts.RunID,
+				"commit":           opts.Commit,
+				"temporal_version": opts.TemporalVersion,
+				"sdk_version":      opts.WorkflowSDKVersion,
+			},
+		}, results)
+		if err != nil {
+			return fmt.Errorf("push results: %w", err)
+		}
+	}
+
+	client := grabana.NewClient(httpClient, opts.GrafanaHost, grabana.WithAPIToken(opts.GrafanaToken))
+	reconciler := reconcile.New(httpClient, opts.GrafanaHost, opts.GrafanaToken)
+
+	folder, err := client.FindOrCreateFolder(ctx, opts.Folder)
+	if err != nil {
+		return fmt.Errorf("find or create folder: %w", err)
+	}
+
+	dashboards, err := reconciler.ListFolderDashboards(ctx, folder.ID)
+	if err != nil {
+		return fmt.Errorf("list folder %s: %w", opts.Folder, err)
+	}
+
+	tags := []string{
+		"benchmark",
+		"run:" + opts.RunID,
+		"commit:" + opts.Commit,
+		"temporal:" + opts.TemporalVersion,
+		"sdk:" + opts.WorkflowSDKVersion,
+	}
+	text := fmt.Sprintf("benchmark run %s (%d results)", opts.RunID, len(results))
+
+	for _, d := range dashboards {
+		err := CreateAnnotation(ctx, httpClient, opts.GrafanaHost, opts.GrafanaToken, Annotation{
+			DashboardUID: d.UID,
+			Start:        opts.Start,
+			End:          opts.End,
+			Text:         text,
+			Tags:         tags,
+		})
+		if err != nil {
+			return fmt.Errorf("annotate dashboard %s: %w", d.UID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,293 @@
+// Package datasources provisions Grafana datasources (Prometheus, Loki,
+// Tempo, ...) idempotently, so benchmark matrix runs standing up a fresh
+// Grafana instance don't need a separate click-through setup step.
+package datasources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BasicAuth configures HTTP basic auth against the datasource, with the
+// password resolved from a SecretRef rather than stored in the YAML.
+type BasicAuth struct {
+	User           string `yaml:"user"`
+	PasswordSecret string `yaml:"passwordSecretRef"`
+}
+
+// TLSConfig configures client TLS against the datasource, each field
+// resolved from a SecretRef.
+type TLSConfig struct {
+	CASecret   string `yaml:"caSecretRef"`
+	CertSecret string `yaml:"certSecretRef"`
+	KeySecret  string `yaml:"keySecretRef"`
+}
+
+// Datasource is one entry under a `datasources:` section.
+type Datasource struct {
+	Name      string                 `yaml:"name"`
+	Type      string                 `yaml:"type"`
+	URL       string                 `yaml:"url"`
+	Access    string                 `yaml:"access"`
+	IsDefault bool                   `yaml:"isDefault"`
+	BasicAuth *BasicAuth             `yaml:"basicAuth"`
+	TLS       *TLSConfig             `yaml:"tls"`
+	JSONData  map[string]interface{} `yaml:"jsonData"`
+}
+
+// Config is the top-level shape of datasources.yaml, or a `datasources:`
+// section embedded in a dashboard YAML file.
+type Config struct {
+	Datasources []Datasource `yaml:"datasources"`
+}
+
+// Load reads a datasources config from path, returning an empty Config if
+// the file doesn't exist, since not every benchmark run provisions its own
+// datasources.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("read datasources file %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("parse datasources file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// resolveSecret resolves a `env:NAME` or `file:relative/path` reference. The
+// file form is resolved relative to secretsDir. An empty ref resolves to "".
+func resolveSecret(ref, secretsDir string) (string, error) {
+	switch {
+	case ref == "":
+		return "", nil
+	case strings.HasPrefix(ref, "env:"):
+		name := strings.TrimPrefix(ref, "env:")
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("secret ref %q: environment variable %s is not set", ref, name)
+		}
+		return value, nil
+	case strings.HasPrefix(ref, "file:"):
+		rel := strings.TrimPrefix(ref, "file:")
+		data, err := os.ReadFile(filepath.Join(secretsDir, rel))
+		if err != nil {
+			return "", fmt.Errorf("secret ref %q: %w", ref, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	default:
+		return "", fmt.Errorf("secret ref %q: must start with \"env:\" or \"file:\"", ref)
+	}
+}
+
+// Provisioner creates or updates datasources against a single Grafana
+// instance.
+type Provisioner struct {
+	httpClient *http.Client
+	host       string
+	token      string
+	secretsDir string
+}
+
+// New builds a Provisioner against the given Grafana host, resolving secret
+// refs relative to secretsDir.
+func New(httpClient *http.Client, host, token, secretsDir string) *Provisioner {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Provisioner{httpClient: httpClient, host: host, token: token, secretsDir: secretsDir}
+}
+
+// Provision creates or updates every datasource in cfg, returning a map of
+// datasource name to UID for callers to thread into dashboard templates.
+func (p *Provisioner) Provision(ctx context.Context, cfg Config) (map[string]string, error) {
+	uids := make(map[string]string, len(cfg.Datasources))
+	for _, ds := range cfg.Datasources {
+		uid, err := p.provisionOne(ctx, ds)
+		if err != nil {
+			return uids, fmt.Errorf("provision datasource %s: %w", ds.Name, err)
+		}
+		uids[ds.Name] = uid
+	}
+	return uids, nil
+}
+
+func (p *Provisioner) provisionOne(ctx context.Context, ds Datasource) (string, error) {
+	body, err := p.payload(ds)
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := p.find(ctx, ds.Name)
+	if err != nil {
+		return "", err
+	}
+
+	if existing == nil {
+		return p.create(ctx, body)
+	}
+
+	body["id"] = existing["id"]
+	if uid, ok := existing["uid"]; ok {
+		body["uid"] = uid
+	}
+	return p.update(ctx, fmt.Sprintf("%v", existing["id"]), body)
+}
+
+// payload builds the JSON body Grafana's datasource API expects, resolving
+// any secret refs first.
+func (p *Provisioner) payload(ds Datasource) (map[string]interface{}, error) {
+	body := map[string]interface{}{
+		"name":      ds.Name,
+		"type":      ds.Type,
+		"url":       ds.URL,
+		"access":    orDefault(ds.Access, "proxy"),
+		"isDefault": ds.IsDefault,
+	}
+	if ds.JSONData != nil {
+		body["jsonData"] = ds.JSONData
+	}
+
+	secureJSONData := map[string]interface{}{}
+
+	if ds.BasicAuth != nil {
+		password, err := resolveSecret(ds.BasicAuth.PasswordSecret, p.secretsDir)
+		if err != nil {
+			return nil, err
+		}
+		body["basicAuth"] = true
+		body["basicAuthUser"] = ds.BasicAuth.User
+		secureJSONData["basicAuthPassword"] = password
+	}
+
+	if ds.TLS != nil {
+		ca, err := resolveSecret(ds.TLS.CASecret, p.secretsDir)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := resolveSecret(ds.TLS.CertSecret, p.secretsDir)
+		if err != nil {
+			return nil, err
+		}
+		key, err := resolveSecret(ds.TLS.KeySecret, p.secretsDir)
+		if err != nil {
+			return nil, err
+		}
+		jsonData, _ := body["jsonData"].(map[string]interface{})
+		if jsonData == nil {
+			jsonData = map[string]interface{}{}
+		}
+		jsonData["tlsAuthWithCACert"] = ca != ""
+		jsonData["tlsAuth"] = cert != "" && key != ""
+		body["jsonData"] = jsonData
+		secureJSONData["tlsCACert"] = ca
+		secureJSONData["tlsClientCert"] = cert
+		secureJSONData["tlsClientKey"] = key
+	}
+
+	if len(secureJSONData) > 0 {
+		body["secureJsonData"] = secureJSONData
+	}
+	return body, nil
+}
+
+func (p *Provisioner) find(ctx context.Context, name string) (map[string]interface{}, error) {
+	resp, err := p.do(ctx, http.MethodGet, "/api/datasources/name/"+name, nil)
+	if err != nil {
+		return nil, fmt.Errorf("lookup datasource %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("lookup datasource %s: unexpected status %s", name, resp.Status)
+	}
+
+	var existing map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&existing); err != nil {
+		return nil, fmt.Errorf("decode datasource %s: %w", name, err)
+	}
+	return existing, nil
+}
+
+func (p *Provisioner) create(ctx context.Context, body map[string]interface{}) (string, error) {
+	resp, err := p.do(ctx, http.MethodPost, "/api/datasources", body)
+	if err != nil {
+		return "", fmt.Errorf("create datasource: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("create datasource: unexpected status %s", resp.Status)
+	}
+	return decodeUID(resp.Body)
+}
+
+func (p *Provisioner) update(ctx context.Context, id string, body map[string]interface{}) (string, error) {
+	resp, err := p.do(ctx, http.MethodPut, "/api/datasources/"+id, body)
+	if err != nil {
+		return "", fmt.Errorf("update datasource %s: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("update datasource %s: unexpected status %s", id, resp.Status)
+	}
+	return decodeUID(resp.Body)
+}
+
+func (p *Provisioner) do(ctx context.Context, method, path string, body map[string]interface{}) (*http.Response, error) {
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.host+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+	req.Header.Set("Content-Type", "application/json")
+	return p.httpClient.Do(req)
+}
+
+func decodeUID(body io.Reader) (string, error) {
+	var out struct {
+		Datasource struct {
+			UID string `json:"uid"`
+		} `json:"datasource"`
+	}
+	if err := json.NewDecoder(body).Decode(&out); err != nil {
+		return "", fmt.Errorf("decode datasource response: %w", err)
+	}
+	return out.Datasource.UID, nil
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,61 @@
+package datasources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveSecretEmpty(t *testing.T) {
+	got, err := resolveSecret("", t.TempDir())
+	if err != nil {
+		t.Fatalf("resolveSecret: %v", err)
+	}
+	if got != "" {
+		t.Errorf("resolveSecret(\"\") = %q, want empty", got)
+	}
+}
+
+func TestResolveSecretEnv(t *testing.T) {
+	t.Setenv("DS_TEST_SECRET", "hunter2")
+	got, err := resolveSecret("env:DS_TEST_SECRET", t.TempDir())
+	if err != nil {
+		t.Fatalf("resolveSecret: %v", err)
+	}
+	if got != "hunter2" {
+		t.Errorf("resolveSecret(env:) = %q, want %q", got, "hunter2")
+	}
+}
+
+func TestResolveSecretEnvMissing(t *testing.T) {
+	if _, err := resolveSecret("env:DS_TEST_SECRET_MISSING", t.TempDir()); err == nil {
+		t.Fatal("resolveSecret: expected error for unset environment variable, got nil")
+	}
+}
+
+func TestResolveSecretFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "password"), []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatalf("write secret file: %v", err)
+	}
+
+	got, err := resolveSecret("file:password", dir)
+	if err != nil {
+		t.Fatalf("resolveSecret: %v", err)
+	}
+	if got != "hunter2" {
+		t.Errorf("resolveSecret(file:) = %q, want %q (trailing whitespace trimmed)", got, "hunter2")
+	}
+}
+
+func TestResolveSecretFileMissing(t *testing.T) {
+	if _, err := resolveSecret("file:does-not-exist", t.TempDir()); err == nil {
+		t.Fatal("resolveSecret: expected error for missing file, got nil")
+	}
+}
+
+func TestResolveSecretInvalidPrefix(t *testing.T) {
+	if _, err := resolveSecret("vault:some/path", t.TempDir()); err == nil {
+		t.Fatal("resolveSecret: expected error for unrecognized ref prefix, got nil")
+	}
+}
@@ -0,0 +1,154 @@
+// Package loader discovers dashboard YAML sources under a directory tree,
+// expands them as Helm-style templates, and extracts each one's target
+// Grafana folder.
+package loader
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File is one discovered dashboard source, after template expansion and
+// front-matter extraction.
+type File struct {
+	Path    string
+	Folder  string // target Grafana folder; empty means the caller's default
+	Content []byte // template-expanded YAML, with any folder directive stripped
+}
+
+var folderDirective = regexp.MustCompile(`^#\s*grabana:folder:\s*(.+)$`)
+
+// Discover walks root for every *.yaml/*.yml file, skipping skipPaths (e.g.
+// the values file and a sibling datasources.yaml) so they're never mistaken
+// for a dashboard.
+func Discover(root string, skipPaths ...string) ([]string, error) {
+	skipAbs := make(map[string]bool, len(skipPaths))
+	for _, p := range skipPaths {
+		if abs, err := filepath.Abs(p); err == nil {
+			skipAbs[abs] = true
+		}
+	}
+
+	var paths []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		ext := filepath.Ext(path)
+		if ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		if abs, _ := filepath.Abs(path); skipAbs[abs] {
+			return nil
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("discover dashboards under %s: %w", root, err)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// LoadValues reads a Helm-style values.yaml into a generic map for template
+// expansion. A missing file yields an empty map rather than an error, since
+// not every benchmark needs templated parameters.
+func LoadValues(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]interface{}{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read values file %s: %w", path, err)
+	}
+
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("parse values file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// Load reads path, expands it as a text/template against values, and
+// extracts its target folder from either a leading `# grabana:folder: <name>`
+// comment or a top-level `folder:` key.
+func Load(path string, values map[string]interface{}) (File, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return File{}, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Parse(string(raw))
+	if err != nil {
+		return File{}, fmt.Errorf("parse template %s: %w", path, err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, values); err != nil {
+		return File{}, fmt.Errorf("render template %s: %w", path, err)
+	}
+
+	folder, body := extractFolder(rendered.Bytes())
+	return File{Path: path, Folder: folder, Content: body}, nil
+}
+
+// extractFolder looks for a `# grabana:folder: <name>` directive on any
+// line, then falls back to a top-level `folder:` key, stripping whichever
+// one it finds so the remainder still parses cleanly as a grabana dashboard
+// document. The body's trailing newline (or lack of one) is preserved to
+// match content, rather than always being dropped.
+func extractFolder(content []byte) (folder string, body []byte) {
+	hadTrailingNewline := bytes.HasSuffix(content, []byte("\n"))
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	var kept []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if folder == "" {
+			if m := folderDirective.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+				folder = strings.TrimSpace(m[1])
+				continue
+			}
+		}
+		kept = append(kept, line)
+	}
+
+	joined := strings.Join(kept, "\n")
+	if hadTrailingNewline && len(kept) > 0 {
+		joined += "\n"
+	}
+	body = []byte(joined)
+
+	if folder != "" {
+		return folder, body
+	}
+
+	var doc struct {
+		Folder string `yaml:"folder"`
+	}
+	if err := yaml.Unmarshal(body, &doc); err == nil && doc.Folder != "" {
+		return doc.Folder, stripTopLevelKey(body, "folder")
+	}
+	return "", body
+}
+
+// stripTopLevelKey removes a single top-level "key: value" line so the
+// dashboard decoder doesn't choke on a field it doesn't recognize.
+func stripTopLevelKey(content []byte, key string) []byte {
+	re := regexp.MustCompile(`(?m)^` + key + `:.*\n?`)
+	return re.ReplaceAll(content, nil)
+}
@@ -0,0 +1,92 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverSkipsNonYAMLAndSkipPaths(t *testing.T) {
+	dir := t.TempDir()
+	values := filepath.Join(dir, "values.yaml")
+	writeFile(t, values, "replicas: 1\n")
+	writeFile(t, filepath.Join(dir, "datasources.yaml"), "datasources: []\n")
+	writeFile(t, filepath.Join(dir, "a.yaml"), "title: a\n")
+	writeFile(t, filepath.Join(dir, "b.yml"), "title: b\n")
+	writeFile(t, filepath.Join(dir, "README.md"), "not a dashboard\n")
+
+	paths, err := Discover(dir, values, filepath.Join(dir, "datasources.yaml"))
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+
+	want := []string{filepath.Join(dir, "a.yaml"), filepath.Join(dir, "b.yml")}
+	if len(paths) != len(want) {
+		t.Fatalf("Discover = %v, want %v", paths, want)
+	}
+	for i, p := range want {
+		if paths[i] != p {
+			t.Errorf("Discover[%d] = %q, want %q", i, paths[i], p)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestExtractFolderFromDirectiveComment(t *testing.T) {
+	content := []byte("# grabana:folder: Temporal Benchmarks\ntitle: stack\nrows: []\n")
+	folder, body := extractFolder(content)
+	if folder != "Temporal Benchmarks" {
+		t.Errorf("folder = %q, want %q", folder, "Temporal Benchmarks")
+	}
+	if string(body) != "title: stack\nrows: []\n" {
+		t.Errorf("body = %q, directive line should be stripped and trailing newline preserved", body)
+	}
+}
+
+func TestExtractFolderFromTopLevelKey(t *testing.T) {
+	content := []byte("title: stack\nfolder: Temporal Benchmarks\nrows: []\n")
+	folder, body := extractFolder(content)
+	if folder != "Temporal Benchmarks" {
+		t.Errorf("folder = %q, want %q", folder, "Temporal Benchmarks")
+	}
+	if got := string(body); got != "title: stack\nrows: []\n" {
+		t.Errorf("body = %q, folder key should be stripped", got)
+	}
+}
+
+func TestExtractFolderAbsentReturnsEmpty(t *testing.T) {
+	content := []byte("title: stack\nrows: []\n")
+	folder, body := extractFolder(content)
+	if folder != "" {
+		t.Errorf("folder = %q, want empty", folder)
+	}
+	if string(body) != string(content) {
+		t.Errorf("body = %q, want unchanged %q", body, content)
+	}
+}
+
+func TestExtractFolderWithoutTrailingNewline(t *testing.T) {
+	content := []byte("# grabana:folder: Temporal Benchmarks\ntitle: stack\nrows: []")
+	folder, body := extractFolder(content)
+	if folder != "Temporal Benchmarks" {
+		t.Errorf("folder = %q, want %q", folder, "Temporal Benchmarks")
+	}
+	if string(body) != "title: stack\nrows: []" {
+		t.Errorf("body = %q, should not gain a trailing newline that wasn't in content", body)
+	}
+}
+
+func TestStripTopLevelKey(t *testing.T) {
+	content := []byte("title: stack\nfolder: Temporal Benchmarks\nrows: []\n")
+	got := string(stripTopLevelKey(content, "folder"))
+	want := "title: stack\nrows: []\n"
+	if got != want {
+		t.Errorf("stripTopLevelKey = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,88 @@
+// Package reconcile tracks which dashboards this tool owns and detects when
+// they've drifted from what was last applied.
+package reconcile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Entry records everything we need to recognize one dashboard across runs:
+// where it came from, what we last pushed, and what we last saw live.
+type Entry struct {
+	SourcePath   string `json:"source_path"`
+	SourceHash   string `json:"source_hash"`   // hash of the source YAML, detects (3) changed files
+	FolderUID    string `json:"folder_uid"`
+	DashboardUID string `json:"dashboard_uid"`
+	AppliedHash  string `json:"applied_hash"` // hash of the dashboard JSON as last pushed, detects (2) live drift
+}
+
+// Manifest is the set of dashboards this tool applied last time, keyed by
+// dashboard UID.
+type Manifest struct {
+	Entries map[string]Entry `json:"entries"`
+}
+
+// NewManifest returns an empty manifest ready to be populated.
+func NewManifest() *Manifest {
+	return &Manifest{Entries: make(map[string]Entry)}
+}
+
+// LoadManifest reads the manifest from path, returning an empty manifest if
+// it doesn't exist yet (e.g. the first run against a fresh checkout).
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewManifest(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read manifest %s: %w", path, err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse manifest %s: %w", path, err)
+	}
+	if m.Entries == nil {
+		m.Entries = make(map[string]Entry)
+	}
+	return &m, nil
+}
+
+// Save writes the manifest to path as indented JSON.
+func (m *Manifest) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write manifest %s: %w", path, err)
+	}
+	return nil
+}
+
+// Put records or overwrites the entry for a dashboard.
+func (m *Manifest) Put(e Entry) {
+	m.Entries[e.DashboardUID] = e
+}
+
+// BySourcePath finds the entry that was last applied from the given source
+// file, if any.
+func (m *Manifest) BySourcePath(path string) (Entry, bool) {
+	for _, e := range m.Entries {
+		if e.SourcePath == path {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// HashContent returns a stable hex-encoded hash for arbitrary file content,
+// used for both source YAML and applied dashboard JSON.
+func HashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,46 @@
+package reconcile
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestManifestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+
+	m := NewManifest()
+	m.Put(Entry{
+		SourcePath:   "dashboards/stack.yaml",
+		SourceHash:   "abc123",
+		FolderUID:    "folder-uid",
+		DashboardUID: "dash-uid",
+		AppliedHash:  "def456",
+	})
+
+	if err := m.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+
+	entry, ok := loaded.BySourcePath("dashboards/stack.yaml")
+	if !ok {
+		t.Fatalf("BySourcePath: entry not found after round trip")
+	}
+	if entry.DashboardUID != "dash-uid" || entry.SourceHash != "abc123" {
+		t.Fatalf("BySourcePath: got %+v, want DashboardUID=dash-uid SourceHash=abc123", entry)
+	}
+}
+
+func TestLoadManifestMissingFileReturnsEmpty(t *testing.T) {
+	m, err := LoadManifest(filepath.Join(t.TempDir(), "does-not-exist.json"))
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	if len(m.Entries) != 0 {
+		t.Fatalf("expected empty manifest, got %d entries", len(m.Entries))
+	}
+}
@@ -0,0 +1,227 @@
+package reconcile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Options controls what a caller does with a Report once it has one;
+// Reconcile itself never mutates Grafana.
+type Options struct {
+	DryRun      bool
+	Prune       bool
+	FailOnDrift bool
+}
+
+// searchResult mirrors the subset of Grafana's /api/search response we need.
+type searchResult struct {
+	UID   string `json:"uid"`
+	Title string `json:"title"`
+	Type  string `json:"type"`
+}
+
+// Report summarizes the three drift conditions we care about.
+type Report struct {
+	Orphans []string // dashboard UIDs live in the folder but absent from the manifest
+	Drifted []string // dashboard UIDs whose live JSON no longer matches what we last applied
+	Changed []string // source paths whose YAML content no longer matches the manifest
+}
+
+// Dirty reports whether any of the three conditions were found.
+func (r Report) Dirty() bool {
+	return len(r.Orphans) > 0 || len(r.Drifted) > 0 || len(r.Changed) > 0
+}
+
+// Reconciler enumerates and fetches dashboards via Grafana's HTTP API
+// directly; grabana exposes lookup-by-UID but not folder listing.
+type Reconciler struct {
+	httpClient *http.Client
+	host       string
+	token      string
+}
+
+// New builds a Reconciler against the given Grafana host, authenticating
+// with token. A nil httpClient falls back to http.DefaultClient.
+func New(httpClient *http.Client, host, token string) *Reconciler {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Reconciler{httpClient: httpClient, host: host, token: token}
+}
+
+func (r *Reconciler) get(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.host+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+r.token)
+	return r.httpClient.Do(req)
+}
+
+func (r *Reconciler) post(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.host+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+r.token)
+	req.Header.Set("Content-Type", "application/json")
+	return r.httpClient.Do(req)
+}
+
+func (r *Reconciler) delete(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, r.host+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+r.token)
+	return r.httpClient.Do(req)
+}
+
+// DeleteDashboard deletes a dashboard by UID via /api/dashboards/uid/<uid>,
+// used to prune orphans found during Reconcile. Deleting a dashboard that's
+// already gone is treated as success.
+func (r *Reconciler) DeleteDashboard(ctx context.Context, uid string) error {
+	resp, err := r.delete(ctx, "/api/dashboards/uid/"+uid)
+	if err != nil {
+		return fmt.Errorf("delete dashboard %s: %w", uid, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("delete dashboard %s: unexpected status %s", uid, resp.Status)
+	}
+	return nil
+}
+
+// PutDashboard creates or overwrites a dashboard via /api/dashboards/db,
+// used by restore to push raw dashboard JSON back into Grafana without
+// going through grabana's YAML-oriented model.
+func (r *Reconciler) PutDashboard(ctx context.Context, folderID uint, dashboard map[string]interface{}) (string, error) {
+	resp, err := r.post(ctx, "/api/dashboards/db", map[string]interface{}{
+		"dashboard": dashboard,
+		"folderId":  folderID,
+		"overwrite": true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("put dashboard: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("put dashboard: unexpected status %s", resp.Status)
+	}
+
+	var out struct {
+		UID string `json:"uid"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("decode put dashboard response: %w", err)
+	}
+	return out.UID, nil
+}
+
+// ListFolderDashboards enumerates every dashboard in the folder identified by
+// folderID, tolerating a folder that doesn't exist (or is empty) yet.
+func (r *Reconciler) ListFolderDashboards(ctx context.Context, folderID uint) ([]searchResult, error) {
+	resp, err := r.get(ctx, fmt.Sprintf("/api/search?folderIds=%d&type=dash-db", folderID))
+	if err != nil {
+		return nil, fmt.Errorf("search folder %d: %w", folderID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("search folder %d: unexpected status %s", folderID, resp.Status)
+	}
+
+	var results []searchResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("decode search response: %w", err)
+	}
+	return results, nil
+}
+
+// FetchDashboardJSON retrieves the live `/api/dashboards/uid/<uid>` payload
+// (dashboard + meta) as-is. A missing dashboard returns (nil, nil) rather
+// than an error, since that's just an orphaned manifest entry for the caller
+// to report separately.
+func (r *Reconciler) FetchDashboardJSON(ctx context.Context, uid string) (json.RawMessage, error) {
+	resp, err := r.get(ctx, "/api/dashboards/uid/"+uid)
+	if err != nil {
+		return nil, fmt.Errorf("fetch dashboard %s: %w", uid, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch dashboard %s: unexpected status %s", uid, resp.Status)
+	}
+
+	var body json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode dashboard %s: %w", uid, err)
+	}
+	return body, nil
+}
+
+// FetchDashboardHash retrieves the live dashboard JSON for uid and hashes it,
+// so callers can compare against an Entry.AppliedHash.
+func (r *Reconciler) FetchDashboardHash(ctx context.Context, uid string) (string, error) {
+	body, err := r.FetchDashboardJSON(ctx, uid)
+	if err != nil {
+		return "", err
+	}
+	if body == nil {
+		return "", nil
+	}
+	return HashContent(body), nil
+}
+
+// Reconcile compares live Grafana state in folderID against manifest (for
+// orphans and drift) and previousSourceHashes against currentSourceHashes,
+// both keyed by source path, for condition (3): YAML files whose content
+// changed since the manifest was last written. Callers must snapshot
+// previousSourceHashes before anything in the run can overwrite the
+// manifest's SourceHash fields, or this will never report anything.
+func (r *Reconciler) Reconcile(ctx context.Context, folderID uint, manifest *Manifest, previousSourceHashes, currentSourceHashes map[string]string) (Report, error) {
+	live, err := r.ListFolderDashboards(ctx, folderID)
+	if err != nil {
+		return Report{}, err
+	}
+
+	var report Report
+	for _, d := range live {
+		entry, owned := manifest.Entries[d.UID]
+		if !owned {
+			report.Orphans = append(report.Orphans, d.UID)
+			continue
+		}
+
+		liveHash, err := r.FetchDashboardHash(ctx, d.UID)
+		if err != nil {
+			return Report{}, err
+		}
+		if liveHash != "" && liveHash != entry.AppliedHash {
+			report.Drifted = append(report.Drifted, d.UID)
+		}
+	}
+
+	for path, prevHash := range previousSourceHashes {
+		if curHash, ok := currentSourceHashes[path]; ok && curHash != prevHash {
+			report.Changed = append(report.Changed, path)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,105 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// stubServer serves /api/search and /api/dashboards/uid/<uid> from fixed
+// in-memory fixtures, enough to exercise Reconcile without a real Grafana.
+func stubServer(t *testing.T, search []searchResult, dashboards map[string]json.RawMessage) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/search", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(search)
+	})
+	mux.HandleFunc("/api/dashboards/uid/", func(w http.ResponseWriter, r *http.Request) {
+		uid := r.URL.Path[len("/api/dashboards/uid/"):]
+		body, ok := dashboards[uid]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestReconcileDetectsOrphansAndDrift(t *testing.T) {
+	srv := stubServer(t,
+		[]searchResult{{UID: "owned-uid"}, {UID: "orphan-uid"}},
+		map[string]json.RawMessage{
+			"owned-uid": json.RawMessage(`{"dashboard":{"title":"drifted live edit"}}`),
+		},
+	)
+
+	manifest := NewManifest()
+	manifest.Put(Entry{SourcePath: "a.yaml", SourceHash: "h1", DashboardUID: "owned-uid", AppliedHash: "original-hash"})
+
+	r := New(srv.Client(), srv.URL, "token")
+	report, err := r.Reconcile(context.Background(), 1, manifest, nil, nil)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	if len(report.Orphans) != 1 || report.Orphans[0] != "orphan-uid" {
+		t.Errorf("Orphans = %v, want [orphan-uid]", report.Orphans)
+	}
+	if len(report.Drifted) != 1 || report.Drifted[0] != "owned-uid" {
+		t.Errorf("Drifted = %v, want [owned-uid]", report.Drifted)
+	}
+}
+
+func TestReconcileDetectsChangedSources(t *testing.T) {
+	srv := stubServer(t, nil, nil)
+	r := New(srv.Client(), srv.URL, "token")
+	manifest := NewManifest()
+
+	cases := []struct {
+		name    string
+		prev    map[string]string
+		current map[string]string
+		want    []string
+	}{
+		{
+			name:    "unchanged source is not reported",
+			prev:    map[string]string{"a.yaml": "h1"},
+			current: map[string]string{"a.yaml": "h1"},
+			want:    nil,
+		},
+		{
+			name:    "changed source is reported",
+			prev:    map[string]string{"a.yaml": "h1"},
+			current: map[string]string{"a.yaml": "h2"},
+			want:    []string{"a.yaml"},
+		},
+		{
+			name:    "source missing from current is not reported",
+			prev:    map[string]string{"a.yaml": "h1"},
+			current: map[string]string{},
+			want:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			report, err := r.Reconcile(context.Background(), 1, manifest, tc.prev, tc.current)
+			if err != nil {
+				t.Fatalf("Reconcile: %v", err)
+			}
+			if len(report.Changed) != len(tc.want) {
+				t.Fatalf("Changed = %v, want %v", report.Changed, tc.want)
+			}
+			for i, path := range tc.want {
+				if report.Changed[i] != path {
+					t.Fatalf("Changed = %v, want %v", report.Changed, tc.want)
+				}
+			}
+		})
+	}
+}